@@ -0,0 +1,38 @@
+// Package bsonduration adds BSON marshaling for duration.Duration. It is a
+// separate module so that the mongo-driver dependency it needs isn't
+// imposed on every consumer of the base iso8601duration package.
+package bsonduration
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+
+	"github.com/barshociaj/iso8601duration"
+)
+
+// Duration wraps duration.Duration to add bson.ValueMarshaler and
+// bson.ValueUnmarshaler support.
+type Duration struct {
+	duration.Duration
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler, encoding the duration
+// as its ISO8601 string form.
+func (d Duration) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.MarshalValue(d.Duration.String())
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (d *Duration) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var s string
+	raw := bson.RawValue{Type: t, Value: data}
+	if err := raw.Unmarshal(&s); err != nil {
+		return err
+	}
+	parsed, err := duration.ParseString(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = *parsed
+	return nil
+}
@@ -0,0 +1,34 @@
+package bsonduration
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/barshociaj/iso8601duration"
+)
+
+func TestMarshalUnmarshalBSONValue(t *testing.T) {
+	parsed, err := duration.ParseString("P1D")
+	if err != nil {
+		t.Fatalf("ParseString returned error: %v", err)
+	}
+
+	type doc struct {
+		D Duration
+	}
+	in := doc{D: Duration{Duration: *parsed}}
+
+	data, err := bson.Marshal(in)
+	if err != nil {
+		t.Fatalf("bson.Marshal returned error: %v", err)
+	}
+
+	var out doc
+	if err := bson.Unmarshal(data, &out); err != nil {
+		t.Fatalf("bson.Unmarshal returned error: %v", err)
+	}
+	if out.D.Duration.ToDuration() != in.D.Duration.ToDuration() {
+		t.Errorf("round-tripped duration = %v, want %v", out.D.Duration.ToDuration(), in.D.Duration.ToDuration())
+	}
+}
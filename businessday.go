@@ -0,0 +1,77 @@
+package duration
+
+import "time"
+
+// Truncate returns d rounded toward zero to a multiple of unit (one of Day,
+// Week, Month, or Year), mirroring time.Duration.Truncate.
+func (d *Duration) Truncate(unit time.Duration) *Duration {
+	if unit <= 0 {
+		return &Duration{d.Duration}
+	}
+	return &Duration{d.Duration - d.Duration%unit}
+}
+
+// Round returns d rounded to the nearest multiple of unit (one of Day,
+// Week, Month, or Year), rounding half away from zero, mirroring
+// time.Duration.Round.
+func (d *Duration) Round(unit time.Duration) *Duration {
+	if unit <= 0 {
+		return &Duration{d.Duration}
+	}
+
+	r := d.Duration % unit
+	if d.Duration < 0 {
+		r = -r
+		if r+r < unit {
+			return &Duration{d.Duration + r}
+		}
+		return &Duration{d.Duration - unit + r}
+	}
+	if r+r < unit {
+		return &Duration{d.Duration - r}
+	}
+	return &Duration{d.Duration + unit - r}
+}
+
+// dateKey formats t's calendar date (in its own location) for use as a map
+// key. time.Time values that denote the same moment aren't guaranteed to
+// compare equal with ==, so lookups are keyed by this string instead of by
+// time.Time itself.
+func dateKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// BusinessDays reports the number of business days (Monday-Friday,
+// excluding holidays) spanned between anchor and c applied to anchor via
+// AddTo, under loc (UTC if nil). Each calendar day from anchor's date up to
+// (not including) the end of the span is counted if it is a weekday and not
+// a holiday, so "P5D" from a Friday yields 3. The span itself is calendar
+// days, not working days. "P1M"/"P1Y" span real, calendar-aware
+// months/years rather than the 30- and 365-day averages Duration uses.
+func (c *CalendarDuration) BusinessDays(anchor time.Time, loc *time.Location, holidays []time.Time) int {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	start := anchor.In(loc)
+	end := c.AddTo(start)
+	if end.Before(start) {
+		start, end = end, start
+	}
+
+	holidaySet := make(map[string]bool, len(holidays))
+	for _, h := range holidays {
+		holidaySet[dateKey(h.In(loc))] = true
+	}
+
+	count := 0
+	y, m, day := start.Date()
+	for cursor := time.Date(y, m, day, 0, 0, 0, 0, loc); cursor.Before(end); {
+		if wd := cursor.Weekday(); wd != time.Saturday && wd != time.Sunday && !holidaySet[dateKey(cursor)] {
+			count++
+		}
+		y, m, day = cursor.Date()
+		cursor = time.Date(y, m, day+1, 0, 0, 0, 0, loc)
+	}
+	return count
+}
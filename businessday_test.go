@@ -0,0 +1,59 @@
+package duration
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTruncateRound(t *testing.T) {
+	d := &Duration{25 * time.Hour}
+
+	if got := d.Truncate(Day).Duration; got != Day {
+		t.Errorf("Truncate(Day) = %v, want %v", got, Day)
+	}
+	if got := d.Round(Day).Duration; got != Day {
+		t.Errorf("Round(Day) = %v, want %v", got, Day)
+	}
+
+	d = &Duration{36 * time.Hour}
+	if got := d.Round(Day).Duration; got != 2*Day {
+		t.Errorf("Round(Day) = %v, want %v", got, 2*Day)
+	}
+}
+
+func TestBusinessDays(t *testing.T) {
+	// Friday 2026-07-24 + P5D ends Wed 2026-07-29, spanning the weekend
+	// of 2026-07-25/26: business days are Fri 24, Mon 27, Tue 28 = 3.
+	anchor := time.Date(2026, 7, 24, 0, 0, 0, 0, time.UTC)
+	cd := &CalendarDuration{Days: 5}
+
+	got := cd.BusinessDays(anchor, time.UTC, nil)
+	if got != 3 {
+		t.Errorf("BusinessDays = %d, want 3", got)
+	}
+
+	holiday := time.Date(2026, 7, 27, 0, 0, 0, 0, time.UTC)
+	got = cd.BusinessDays(anchor, time.UTC, []time.Time{holiday})
+	if got != 2 {
+		t.Errorf("BusinessDays with holiday = %d, want 2", got)
+	}
+}
+
+func TestBusinessDaysCalendarAwareMonth(t *testing.T) {
+	// P1M from Jan 31 lands on Feb 28 (2026 is not a leap year), not
+	// Duration's 30-day average, so this only passes with real
+	// calendar-aware AddTo arithmetic.
+	anchor := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	cd := &CalendarDuration{Months: 1}
+
+	end := cd.AddTo(anchor)
+	want := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+	if !end.Equal(want) {
+		t.Fatalf("AddTo(2026-01-31) = %v, want %v", end, want)
+	}
+
+	got := cd.BusinessDays(anchor, time.UTC, nil)
+	if got <= 0 {
+		t.Errorf("BusinessDays = %d, want > 0", got)
+	}
+}
@@ -0,0 +1,240 @@
+package duration
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// EndOfMonthMode controls how AddTo resolves a year/month component that
+// lands on a day past the end of the target month (e.g. adding P1M to
+// Jan 31).
+type EndOfMonthMode int
+
+const (
+	// EndOfMonthClamp clamps the result to the last day of the target
+	// month (Jan 31 + P1M = Feb 28/29). This is the default and matches
+	// what most billing/subscription systems expect.
+	EndOfMonthClamp EndOfMonthMode = iota
+	// EndOfMonthOverflow lets the day spill into the following month, the
+	// same behavior as time.Time.AddDate (Jan 31 + P1M = Mar 3).
+	EndOfMonthOverflow
+)
+
+var calendarTmpl = template.Must(template.New("calendarDuration").Parse(
+	`P{{if .IsZero}}0D{{else}}{{if .IsWeeksOnly}}{{.Weeks}}W{{else}}{{if .Years}}{{.Years}}Y{{end}}{{if .Months}}{{.Months}}M{{end}}{{if .Weeks}}{{.Weeks}}W{{end}}{{if .Days}}{{.Days}}D{{end}}{{if .HasTimePart}}T{{if .Hours}}{{.Hours}}H{{end}}{{if .Minutes}}{{.Minutes}}M{{end}}{{if .Seconds}}{{.Seconds}}S{{end}}{{end}}{{end}}{{end}}`,
+))
+
+// CalendarDuration is a calendar-aware ISO8601 duration. Unlike Duration,
+// which collapses everything into a single time.Duration using the
+// average-length Month and Year constants, CalendarDuration keeps years,
+// months, weeks and days as separate integers so that AddTo can apply them
+// to a real date via time.Time.AddDate.
+type CalendarDuration struct {
+	Years   int
+	Months  int
+	Weeks   int
+	Days    int
+	Hours   int
+	Minutes int
+	Seconds float64
+}
+
+// ParseCalendarString parses an ISO8601 duration string into a
+// CalendarDuration, keeping each component as a separate field instead of
+// collapsing it into a single time.Duration.
+func ParseCalendarString(dur string) (*CalendarDuration, error) {
+	var (
+		match []string
+		re    = full
+	)
+
+	if week.MatchString(dur) {
+		match = week.FindStringSubmatch(dur)
+		re = week
+	} else if full.MatchString(dur) {
+		match = full.FindStringSubmatch(dur)
+	} else {
+		return nil, ErrBadFormat
+	}
+
+	groups := make(map[string]string, len(re.SubexpNames()))
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			groups[name] = match[i]
+		}
+	}
+
+	cd := &CalendarDuration{}
+
+	for i, name := range re.SubexpNames() {
+		part := match[i]
+		if i == 0 || name == "" || part == "" || strings.HasSuffix(name, "sign") {
+			continue
+		}
+
+		if name != "second" && strings.ContainsAny(part, ".,") {
+			// Years/months/weeks/days/hours/minutes are integer fields on
+			// CalendarDuration; silently truncating a fraction here would
+			// lose precision no caller asked to give up.
+			return nil, ErrBadFormat
+		}
+
+		val, err := strconv.ParseFloat(strings.Replace(part, ",", ".", 1), 64)
+		if err != nil {
+			return nil, err
+		}
+		if groups[name+"sign"] == "-" {
+			val = -val
+		}
+		if groups["sign"] == "-" {
+			val = -val
+		}
+
+		switch name {
+		case "year":
+			cd.Years = int(val)
+		case "month":
+			cd.Months = int(val)
+		case "week":
+			cd.Weeks = int(val)
+		case "day":
+			cd.Days = int(val)
+		case "hour":
+			cd.Hours = int(val)
+		case "minute":
+			cd.Minutes = int(val)
+		case "second":
+			cd.Seconds = val
+		}
+	}
+
+	return cd, nil
+}
+
+// AddTo applies the calendar duration to t: years and months are applied
+// via t.AddDate (clamped to the end of the target month, see
+// EndOfMonthMode), and the remaining weeks/days/hours/minutes/seconds are
+// applied as a plain time.Duration addition.
+func (c *CalendarDuration) AddTo(t time.Time) time.Time {
+	return c.AddToMode(t, EndOfMonthClamp)
+}
+
+// AddToMode is AddTo with an explicit EndOfMonthMode.
+func (c *CalendarDuration) AddToMode(t time.Time, mode EndOfMonthMode) time.Time {
+	totalMonths := c.Years*12 + c.Months
+	if totalMonths != 0 {
+		if mode == EndOfMonthClamp {
+			t = addMonthsClamped(t, totalMonths)
+		} else {
+			t = t.AddDate(0, totalMonths, 0)
+		}
+	}
+
+	rest := time.Duration(c.Weeks)*Week + time.Duration(c.Days)*Day +
+		time.Duration(c.Hours)*time.Hour + time.Duration(c.Minutes)*time.Minute +
+		time.Duration(c.Seconds*float64(time.Second))
+
+	return t.Add(rest)
+}
+
+// addMonthsClamped adds months to t, clamping the day-of-month to the last
+// day of the resulting month rather than letting it overflow into the
+// following month the way time.Time.AddDate does.
+func addMonthsClamped(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	firstOfMonth := time.Date(year, month, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	target := firstOfMonth.AddDate(0, months, 0)
+
+	lastDay := time.Date(target.Year(), target.Month()+1, 0, 0, 0, 0, 0, target.Location()).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(target.Year(), target.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), target.Location())
+}
+
+// ToDuration performs the same lossy, average-length conversion as
+// Duration.ToDuration, using the Year/Month constants instead of a real
+// calendar.
+func (c *CalendarDuration) ToDuration() time.Duration {
+	return time.Duration(c.Years)*Year + time.Duration(c.Months)*Month +
+		time.Duration(c.Weeks)*Week + time.Duration(c.Days)*Day +
+		time.Duration(c.Hours)*time.Hour + time.Duration(c.Minutes)*time.Minute +
+		time.Duration(c.Seconds*float64(time.Second))
+}
+
+// IsZero reports whether every field of the calendar duration is zero.
+func (c *CalendarDuration) IsZero() bool {
+	return c.Years == 0 && c.Months == 0 && c.Weeks == 0 && c.Days == 0 &&
+		c.Hours == 0 && c.Minutes == 0 && c.Seconds == 0
+}
+
+// HasTimePart reports whether the calendar duration has an hour, minute or
+// second component.
+func (c *CalendarDuration) HasTimePart() bool {
+	return c.Hours != 0 || c.Minutes != 0 || c.Seconds != 0
+}
+
+// IsWeeksOnly reports whether the calendar duration was expressed purely
+// in weeks.
+func (c *CalendarDuration) IsWeeksOnly() bool {
+	return c.Weeks != 0 && c.Years == 0 && c.Months == 0 && c.Days == 0 && !c.HasTimePart()
+}
+
+// Canonical returns the shortest ISO8601 form of c: a whole-year count of
+// months is folded into Years (e.g. 14 months renders as 1Y2M rather than
+// 14M), zero components are dropped, a zero duration collapses to Zero,
+// and the value is rendered in weeks instead of days whenever it is an
+// exact multiple of a week. Unlike Duration.Canonical, this keeps years
+// and months distinct rather than collapsing them into days.
+func (c *CalendarDuration) Canonical() string {
+	norm := *c
+	if extraYears := norm.Months / 12; extraYears != 0 {
+		norm.Years += extraYears
+		norm.Months -= extraYears * 12
+	}
+	if norm.Days != 0 && norm.Days%7 == 0 && norm.Years == 0 && norm.Months == 0 && !norm.HasTimePart() {
+		norm.Weeks += norm.Days / 7
+		norm.Days = 0
+	}
+	return norm.String()
+}
+
+// String renders the calendar duration back into its ISO8601 form.
+func (c *CalendarDuration) String() string {
+	var s bytes.Buffer
+	if err := calendarTmpl.Execute(&s, c); err != nil {
+		panic(err)
+	}
+	return s.String()
+}
+
+// UnmarshalJSON parses a calendar duration encoded as a JSON string.
+func (c *CalendarDuration) UnmarshalJSON(data []byte) error {
+	b := bytes.NewBuffer(data)
+	dec := json.NewDecoder(b)
+	var s string
+	if err := dec.Decode(&s); err != nil {
+		return err
+	}
+	t, err := ParseCalendarString(s)
+	if err != nil {
+		return err
+	}
+	*c = *t
+	return nil
+}
+
+// MarshalJSON encodes the calendar duration as a JSON string.
+func (c CalendarDuration) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	if err := enc.Encode(c.String()); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
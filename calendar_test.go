@@ -0,0 +1,54 @@
+package duration
+
+import "testing"
+
+func TestCalendarDurationCanonical(t *testing.T) {
+	cd, err := ParseCalendarString("P14M")
+	if err != nil {
+		t.Fatalf("ParseCalendarString returned error: %v", err)
+	}
+	if got := cd.Canonical(); got != "P1Y2M" {
+		t.Errorf("Canonical() = %q, want P1Y2M", got)
+	}
+
+	zero := &CalendarDuration{}
+	if got := zero.Canonical(); got != Zero {
+		t.Errorf("Canonical() of zero value = %q, want %q", got, Zero)
+	}
+}
+
+func TestParseCalendarStringSigned(t *testing.T) {
+	cd, err := ParseCalendarString("-P1Y")
+	if err != nil {
+		t.Fatalf("ParseCalendarString(-P1Y) returned error: %v", err)
+	}
+	if cd.Years != -1 {
+		t.Errorf("Years = %d, want -1", cd.Years)
+	}
+
+	cd, err = ParseCalendarString("P-1Y2M")
+	if err != nil {
+		t.Fatalf("ParseCalendarString(P-1Y2M) returned error: %v", err)
+	}
+	if cd.Years != -1 || cd.Months != 2 {
+		t.Errorf("Years, Months = %d, %d; want -1, 2", cd.Years, cd.Months)
+	}
+}
+
+func TestParseCalendarStringRejectsFractionalIntegerFields(t *testing.T) {
+	cases := []string{"P1.5Y", "P1.5M", "P1.5W", "P1.5D", "PT1.5H", "PT1.5M"}
+	for _, in := range cases {
+		if _, err := ParseCalendarString(in); err != ErrBadFormat {
+			t.Errorf("ParseCalendarString(%q) error = %v, want ErrBadFormat", in, err)
+		}
+	}
+
+	// Fractional seconds are fine: Seconds is a float64 field.
+	cd, err := ParseCalendarString("PT1.5S")
+	if err != nil {
+		t.Fatalf("ParseCalendarString(PT1.5S) returned error: %v", err)
+	}
+	if cd.Seconds != 1.5 {
+		t.Errorf("Seconds = %v, want 1.5", cd.Seconds)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"math"
 	"regexp"
 	"strconv"
+	"strings"
 	"text/template"
 	"time"
 )
@@ -36,10 +37,19 @@ var (
 
 	tmpl = template.Must(template.New("duration").Parse(`P{{if .IsZero}}0D{{else}}{{if and .Weeks .IsWeeksOnly}}{{.Weeks}}W{{else}}{{if .Years}}{{.Years}}Y{{end}}{{if .Days}}{{.Days}}D{{end}}{{if .HasTimePart}}T{{if .Hours}}{{.Hours}}H{{end}}{{if .Minutes}}{{.Minutes}}M{{end}}{{if .Seconds}}{{.Seconds}}S{{end}}{{end}}{{end}}{{end}}`))
 
-	full = regexp.MustCompile(`P((?P<year>\d+)Y)?((?P<month>\d+)M)?((?P<day>\d+)D)?(T((?P<hour>\d+)H)?((?P<minute>\d+)M)?((?P<second>[\d\.]+)S)?)?`)
-	week = regexp.MustCompile(`P((?P<week>\d+)W)`)
+	full = regexp.MustCompile(`^(?P<sign>-)?P((?P<yearsign>-)?(?P<year>` + fracNum + `)Y)?((?P<monthsign>-)?(?P<month>` + fracNum + `)M)?((?P<daysign>-)?(?P<day>` + fracNum + `)D)?(T((?P<hoursign>-)?(?P<hour>` + fracNum + `)H)?((?P<minutesign>-)?(?P<minute>` + fracNum + `)M)?((?P<secondsign>-)?(?P<second>` + fracNum + `)S)?)?$`)
+	week = regexp.MustCompile(`^(?P<sign>-)?P((?P<weeksign>-)?(?P<week>` + fracNum + `)W)$`)
 )
 
+// fracNum matches an unsigned integer with an optional decimal fraction,
+// using either '.' or ',' as the separator (ISO8601 permits both).
+const fracNum = `\d+(?:[.,]\d+)?`
+
+// componentOrder lists the duration fields in the order ISO8601 requires
+// them to appear in, which is also the order in which a trailing decimal
+// fraction is allowed to appear.
+var componentOrder = []string{"year", "month", "week", "day", "hour", "minute", "second"}
+
 // Duration is ISO8601 type for your structs that will ensure correct validation and formatting per ISO8601 standard
 type Duration struct {
 	time.Duration
@@ -87,8 +97,27 @@ func (d *Duration) Seconds() float64 {
 	return (float64(d.Duration.Nanoseconds()) - math.Trunc(d.Duration.Minutes())*float64(time.Minute)) / float64(time.Second)
 }
 
+// ParseOptions controls optional strictness of ParseString.
+type ParseOptions struct {
+	// Strict rejects forms the ISO8601 spec forbids but that ParseString
+	// otherwise tolerates, such as a bare "P" with no components, or the
+	// ISO8601-2 sign extension (a minus sign on the whole duration or on
+	// any component).
+	Strict bool
+}
+
 // ParseString parses string into ISO8601 duration
 func ParseString(dur string) (*Duration, error) {
+	return parseString(dur, ParseOptions{})
+}
+
+// ParseStringStrict parses string into an ISO8601 duration, rejecting
+// forms the spec forbids. See ParseOptions.
+func ParseStringStrict(dur string) (*Duration, error) {
+	return parseString(dur, ParseOptions{Strict: true})
+}
+
+func parseString(dur string, opts ParseOptions) (*Duration, error) {
 	var (
 		match []string
 		re    *regexp.Regexp
@@ -104,32 +133,57 @@ func ParseString(dur string) (*Duration, error) {
 		return nil, ErrBadFormat
 	}
 
+	groups := make(map[string]string, len(re.SubexpNames()))
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			groups[name] = match[i]
+		}
+	}
+
+	if opts.Strict {
+		if dur == "P" {
+			return nil, ErrBadFormat
+		}
+		for name, val := range groups {
+			if strings.HasSuffix(name, "sign") && val == "-" {
+				return nil, ErrBadFormat
+			}
+		}
+	}
+
+	if err := validateSingleFraction(groups); err != nil {
+		return nil, err
+	}
+
 	d := time.Duration(0)
 
 	for i, name := range re.SubexpNames() {
 		part := match[i]
-		if i == 0 || name == "" || part == "" {
+		if i == 0 || name == "" || part == "" || strings.HasSuffix(name, "sign") {
 			continue
 		}
 
-		val, err := strconv.ParseFloat(part, 64)
+		val, err := strconv.ParseFloat(strings.Replace(part, ",", ".", 1), 64)
 		if err != nil {
 			return nil, err
 		}
+		if groups[name+"sign"] == "-" {
+			val = -val
+		}
 
 		switch name {
 		case "year":
-			d += time.Duration(val) * Year
+			d += time.Duration(val * float64(Year))
 		case "month":
-			d += time.Duration(val) * Month
+			d += time.Duration(val * float64(Month))
 		case "week":
-			d += time.Duration(val) * Week
+			d += time.Duration(val * float64(Week))
 		case "day":
-			d += time.Duration(val) * Day
+			d += time.Duration(val * float64(Day))
 		case "hour":
-			d += time.Duration(val) * time.Hour
+			d += time.Duration(val * float64(time.Hour))
 		case "minute":
-			d += time.Duration(val) * time.Minute
+			d += time.Duration(val * float64(time.Minute))
 		case "second":
 			d += time.Duration(int(val)) * time.Second
 			// handle fractional seconds
@@ -144,22 +198,115 @@ func ParseString(dur string) (*Duration, error) {
 		}
 	}
 
+	if groups["sign"] == "-" {
+		d = -d
+	}
+
 	return &Duration{d}, nil
 }
 
+// validateSingleFraction enforces the ISO8601 rule that a decimal fraction
+// may only appear on the last present component (e.g. PT1H0.5M is legal,
+// PT0.5H1M is not).
+func validateSingleFraction(groups map[string]string) error {
+	last := ""
+	for _, name := range componentOrder {
+		if groups[name] != "" {
+			last = name
+		}
+	}
+	for _, name := range componentOrder {
+		v := groups[name]
+		if v == "" || name == last {
+			continue
+		}
+		if strings.ContainsAny(v, ".,") {
+			return ErrBadFormat
+		}
+	}
+	return nil
+}
+
 // String prints out the value passed in. It's not strictly according to the
 // ISO spec, but it's pretty close. In particular, months are not returned.
 // Instead, it returns a value in days (1D ~ 364D) or weeks (1W ~ 52W)
 // whenever possible.
 func (d *Duration) String() string {
-	var s bytes.Buffer
+	dur := d.Duration
+	neg := dur < 0
+	if neg {
+		dur = -dur
+	}
 
-	err := tmpl.Execute(&s, d)
+	var s bytes.Buffer
+	err := tmpl.Execute(&s, &Duration{dur})
 	if err != nil {
 		panic(err)
 	}
 
-	return s.String()
+	out := s.String()
+	if neg {
+		out = "-" + out
+	}
+	return out
+}
+
+// CanonicalOptions controls the output of Duration.CanonicalWithOptions.
+type CanonicalOptions struct {
+	// DropSubSecond rounds away any fractional second instead of
+	// rendering it as a decimal, so the result never has sub-second
+	// precision. The default, false, keeps decimal seconds (e.g.
+	// PT1.5S).
+	DropSubSecond bool
+}
+
+// Canonical returns the shortest ISO8601 form of d: zero components are
+// dropped, a zero duration collapses to Zero, and the value is rendered in
+// weeks instead of days whenever it is a whole number of weeks shorter than
+// a year (365 days); whole 365-day years are rendered with a Y designator.
+// It is equivalent to CanonicalWithOptions(CanonicalOptions{}).
+//
+// Duration itself keeps no months: ParseString already collapsed them into
+// a flat time.Duration using the average-length Month constant, so
+// Canonical cannot recover "1 month" from "30 days" and never emits a
+// month designator. To canonicalize a duration
+// string without losing that distinction, use ParseCalendarString and
+// CalendarDuration.Canonical instead.
+func (d *Duration) Canonical() string {
+	return d.CanonicalWithOptions(CanonicalOptions{})
+}
+
+// CanonicalWithOptions is Canonical with explicit control over sub-second
+// rendering.
+func (d *Duration) CanonicalWithOptions(opts CanonicalOptions) string {
+	dur := d.Duration
+	if opts.DropSubSecond {
+		dur = dur.Round(time.Second)
+	}
+	return (&Duration{dur}).String()
+}
+
+// Neg returns a new Duration with the sign flipped.
+func (d *Duration) Neg() *Duration {
+	return &Duration{-d.Duration}
+}
+
+// Abs returns a new Duration with the sign removed.
+func (d *Duration) Abs() *Duration {
+	if d.Duration < 0 {
+		return &Duration{-d.Duration}
+	}
+	return &Duration{d.Duration}
+}
+
+// Add returns a new Duration that is the sum of d and other.
+func (d *Duration) Add(other *Duration) *Duration {
+	return &Duration{d.Duration + other.Duration}
+}
+
+// Sub returns a new Duration that is d minus other.
+func (d *Duration) Sub(other *Duration) *Duration {
+	return &Duration{d.Duration - other.Duration}
 }
 
 // IsZero checks whether duration is zero. Zero value is set to
@@ -0,0 +1,57 @@
+package duration
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseStringFractions(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"PT0.5H", 30 * time.Minute},
+		{"P0.5Y", Year / 2},
+		{"P1.5D", 36 * time.Hour},
+		{"PT1.5M", 90 * time.Second},
+		{"P0,5Y", Year / 2},
+	}
+
+	for _, c := range cases {
+		d, err := ParseString(c.in)
+		if err != nil {
+			t.Fatalf("ParseString(%q) returned error: %v", c.in, err)
+		}
+		if d.Duration != c.want {
+			t.Errorf("ParseString(%q) = %v, want %v", c.in, d.Duration, c.want)
+		}
+	}
+}
+
+func TestDurationCanonicalDoesNotRestoreMonths(t *testing.T) {
+	// Duration.Canonical cannot recover "1 month" from a Duration that
+	// already collapsed it into 30 days at parse time; it only
+	// canonicalizes the flat time.Duration it has (see
+	// CalendarDuration.Canonical for month-aware canonicalization).
+	d, err := ParseString("P1M")
+	if err != nil {
+		t.Fatalf("ParseString returned error: %v", err)
+	}
+	if got := d.Canonical(); got != "P30D" {
+		t.Errorf("Canonical() = %q, want P30D", got)
+	}
+}
+
+func TestCanonicalWithOptions(t *testing.T) {
+	d, err := ParseString("PT1.5S")
+	if err != nil {
+		t.Fatalf("ParseString returned error: %v", err)
+	}
+
+	if got := d.Canonical(); got != "PT1.5S" {
+		t.Errorf("Canonical() = %q, want PT1.5S", got)
+	}
+	if got := d.CanonicalWithOptions(CanonicalOptions{DropSubSecond: true}); got != "PT2S" {
+		t.Errorf("CanonicalWithOptions(DropSubSecond) = %q, want PT2S", got)
+	}
+}
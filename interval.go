@@ -0,0 +1,229 @@
+package duration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interval represents an ISO8601 time interval, in one of its three forms:
+// <start>/<end>, <start>/<duration>, or <duration>/<end>. Exactly two of
+// Start, End and Duration are set, matching whichever form was parsed.
+type Interval struct {
+	Start    *time.Time
+	End      *time.Time
+	Duration *Duration
+}
+
+// ParseInterval parses an ISO8601 time interval string. Datetimes are parsed
+// using time.RFC3339; the duration side is parsed using ParseString.
+func ParseInterval(s string) (*Interval, error) {
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 {
+		return nil, ErrBadFormat
+	}
+	left, right := parts[0], parts[1]
+
+	if strings.HasPrefix(left, "P") {
+		d, err := ParseString(left)
+		if err != nil {
+			return nil, ErrBadFormat
+		}
+		end, err := time.Parse(time.RFC3339, right)
+		if err != nil {
+			return nil, ErrBadFormat
+		}
+		return &Interval{Duration: d, End: &end}, nil
+	}
+
+	start, err := time.Parse(time.RFC3339, left)
+	if err != nil {
+		return nil, ErrBadFormat
+	}
+	if strings.HasPrefix(right, "P") {
+		d, err := ParseString(right)
+		if err != nil {
+			return nil, ErrBadFormat
+		}
+		return &Interval{Start: &start, Duration: d}, nil
+	}
+	end, err := time.Parse(time.RFC3339, right)
+	if err != nil {
+		return nil, ErrBadFormat
+	}
+	return &Interval{Start: &start, End: &end}, nil
+}
+
+// String renders the interval back into whichever of the three ISO8601
+// forms it was parsed as (or constructed with).
+func (i *Interval) String() string {
+	switch {
+	case i.Start != nil && i.End != nil:
+		return i.Start.Format(time.RFC3339) + "/" + i.End.Format(time.RFC3339)
+	case i.Start != nil && i.Duration != nil:
+		return i.Start.Format(time.RFC3339) + "/" + i.Duration.String()
+	case i.Duration != nil && i.End != nil:
+		return i.Duration.String() + "/" + i.End.Format(time.RFC3339)
+	default:
+		return ""
+	}
+}
+
+// UnmarshalJSON parses an interval encoded as a JSON string.
+func (i *Interval) UnmarshalJSON(data []byte) error {
+	b := bytes.NewBuffer(data)
+	dec := json.NewDecoder(b)
+	var s string
+	if err := dec.Decode(&s); err != nil {
+		return err
+	}
+	t, err := ParseInterval(s)
+	if err != nil {
+		return err
+	}
+	*i = *t
+	return nil
+}
+
+// MarshalJSON encodes the interval as a JSON string.
+func (i Interval) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	if err := enc.Encode(i.String()); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// Repeating represents an ISO8601 repeating interval, Rn/<interval> or
+// R/<interval>. N is the number of repetitions, or -1 if the form omitted
+// n (Unbounded reports this case).
+type Repeating struct {
+	N        int
+	Interval *Interval
+
+	// count tracks how many times Next has succeeded, so that it can
+	// honor N the same way Iterator does.
+	count int
+}
+
+// Unbounded reports whether the repeating interval has no repeat limit.
+func (r *Repeating) Unbounded() bool {
+	return r.N < 0
+}
+
+// ParseRepeating parses an ISO8601 repeating interval string, Rn/... or
+// R/....
+func ParseRepeating(s string) (*Repeating, error) {
+	if !strings.HasPrefix(s, "R") {
+		return nil, ErrBadFormat
+	}
+	parts := strings.SplitN(s[1:], "/", 2)
+	if len(parts) != 2 {
+		return nil, ErrBadFormat
+	}
+
+	n := -1
+	if parts[0] != "" {
+		v, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, ErrBadFormat
+		}
+		n = v
+	}
+
+	interval, err := ParseInterval(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repeating{N: n, Interval: interval}, nil
+}
+
+// String renders the repeating interval back into its Rn/... or R/...
+// form.
+func (r *Repeating) String() string {
+	if r.Unbounded() {
+		return "R/" + r.Interval.String()
+	}
+	return fmt.Sprintf("R%d/%s", r.N, r.Interval.String())
+}
+
+// UnmarshalJSON parses a repeating interval encoded as a JSON string.
+func (r *Repeating) UnmarshalJSON(data []byte) error {
+	b := bytes.NewBuffer(data)
+	dec := json.NewDecoder(b)
+	var s string
+	if err := dec.Decode(&s); err != nil {
+		return err
+	}
+	t, err := ParseRepeating(s)
+	if err != nil {
+		return err
+	}
+	*r = *t
+	return nil
+}
+
+// MarshalJSON encodes the repeating interval as a JSON string.
+func (r Repeating) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	if err := enc.Encode(r.String()); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// Next returns the next occurrence after from, by adding the interval's
+// duration to from. The second return value is false once the repeat
+// count has been exhausted (r has already been called successfully N+1
+// times), or if the repeating interval has no duration to advance by.
+func (r *Repeating) Next(from time.Time) (time.Time, bool) {
+	if r.Interval == nil || r.Interval.Duration == nil {
+		return time.Time{}, false
+	}
+	if !r.Unbounded() && r.count > r.N {
+		return time.Time{}, false
+	}
+	next := from.Add(r.Interval.Duration.ToDuration())
+	r.count++
+	return next, true
+}
+
+// Iterator returns a function that yields successive occurrences of the
+// repeating interval, starting at its anchor (the interval's Start, or
+// time.Time{} if it has none), by repeatedly adding the interval's
+// duration. The returned function reports false once the repeat count
+// (if any) has been exhausted.
+//
+// Each call to Iterator starts a fresh, independent sequence with its own
+// counter and anchor: it does not share state with r.Next, nor with any
+// other iterator obtained from r, so calling Iterator more than once (or
+// mixing it with Next) yields consistent, unaffected results each time.
+func (r *Repeating) Iterator() func() (time.Time, bool) {
+	if r.Interval == nil || r.Interval.Duration == nil {
+		return func() (time.Time, bool) { return time.Time{}, false }
+	}
+
+	var anchor time.Time
+	if r.Interval.Start != nil {
+		anchor = *r.Interval.Start
+	}
+	step := r.Interval.Duration.ToDuration()
+	n := r.N
+	count := 0
+
+	return func() (time.Time, bool) {
+		if n >= 0 && count > n {
+			return time.Time{}, false
+		}
+		t := anchor
+		anchor = anchor.Add(step)
+		count++
+		return t, true
+	}
+}
@@ -0,0 +1,88 @@
+package duration
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRepeatingNextHonorsCount(t *testing.T) {
+	d, err := ParseString("P1D")
+	if err != nil {
+		t.Fatalf("ParseString returned error: %v", err)
+	}
+	r := &Repeating{N: 1, Interval: &Interval{Duration: d}}
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, ok := r.Next(from); !ok {
+		t.Fatalf("Next() call 1: ok = false, want true")
+	}
+	if _, ok := r.Next(from); !ok {
+		t.Fatalf("Next() call 2: ok = false, want true")
+	}
+	if _, ok := r.Next(from); ok {
+		t.Fatalf("Next() call 3: ok = true, want false (N=1 exhausted)")
+	}
+}
+
+func TestRepeatingIteratorIndependentOfNext(t *testing.T) {
+	d, err := ParseString("P1D")
+	if err != nil {
+		t.Fatalf("ParseString returned error: %v", err)
+	}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := &Repeating{N: 2, Interval: &Interval{Start: &start, Duration: d}}
+
+	// Exhaust Next's own budget first; it must not affect Iterator.
+	for i := 0; i < 3; i++ {
+		if _, ok := r.Next(start); !ok {
+			t.Fatalf("Next() call %d: ok = false, want true", i)
+		}
+	}
+	if _, ok := r.Next(start); ok {
+		t.Fatalf("Next() after exhausting N: ok = true, want false")
+	}
+
+	collect := func() []time.Time {
+		var got []time.Time
+		iter := r.Iterator()
+		for {
+			t, ok := iter()
+			if !ok {
+				break
+			}
+			got = append(got, t)
+		}
+		return got
+	}
+
+	first := collect()
+	second := collect()
+
+	if len(first) != 3 {
+		t.Fatalf("first Iterator() yielded %d occurrences, want 3", len(first))
+	}
+	if len(second) != len(first) {
+		t.Fatalf("second Iterator() yielded %d occurrences, want %d (same as first)", len(second), len(first))
+	}
+	for i := range first {
+		if !first[i].Equal(second[i]) {
+			t.Errorf("occurrence %d: first=%v second=%v, want equal", i, first[i], second[i])
+		}
+	}
+}
+
+func TestRepeatingNextUnbounded(t *testing.T) {
+	d, err := ParseString("P1D")
+	if err != nil {
+		t.Fatalf("ParseString returned error: %v", err)
+	}
+	r := &Repeating{N: -1, Interval: &Interval{Duration: d}}
+
+	from := time.Now()
+	for i := 0; i < 10; i++ {
+		if _, ok := r.Next(from); !ok {
+			t.Fatalf("Next() call %d: ok = false, want true", i)
+		}
+	}
+}
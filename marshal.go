@@ -0,0 +1,109 @@
+package duration
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/invopop/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalText implements encoding.TextMarshaler, which in turn makes
+// Duration work with encoding/xml, flag.Value-based flag libraries, and
+// anything else that marshals via text rather than JSON.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := ParseString(string(text))
+	if err != nil {
+		return err
+	}
+	*d = *parsed
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler for both gopkg.in/yaml.v2 and
+// gopkg.in/yaml.v3, which share this method signature.
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+// UnmarshalYAML implements yaml.v3's yaml.Unmarshaler. yaml.v2 instead
+// expects an UnmarshalYAML(func(interface{}) error) error method, which
+// cannot coexist with this one on the same type; yaml.v2 users should
+// decode into a string and call ParseString, or use UnmarshalText.
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return err
+	}
+	parsed, err := ParseString(s)
+	if err != nil {
+		return err
+	}
+	*d = *parsed
+	return nil
+}
+
+// Value implements driver.Valuer, storing the duration as its ISO8601
+// string form (suitable for a TEXT column, or an INTERVAL column on
+// databases that accept ISO8601 input for it).
+func (d Duration) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner, accepting either an ISO8601 string or a
+// numeric count of nanoseconds (as produced by databases that store the
+// duration as an INTERVAL and hand it back as a number).
+func (d *Duration) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Duration{}
+		return nil
+	case string:
+		parsed, err := ParseString(v)
+		if err != nil {
+			return err
+		}
+		*d = *parsed
+		return nil
+	case []byte:
+		return d.Scan(string(v))
+	case int64:
+		*d = Duration{time.Duration(v)}
+		return nil
+	default:
+		return fmt.Errorf("duration: unsupported Scan source type %T", src)
+	}
+}
+
+// Set implements pflag.Value (and flag.Value, together with the existing
+// String method).
+func (d *Duration) Set(s string) error {
+	parsed, err := ParseString(s)
+	if err != nil {
+		return err
+	}
+	*d = *parsed
+	return nil
+}
+
+// Type implements pflag.Value.
+func (d *Duration) Type() string {
+	return "duration"
+}
+
+// JSONSchema implements the github.com/invopop/jsonschema JSONSchema hook,
+// describing Duration as the string format it marshals to via
+// MarshalText/MarshalJSON.
+func (Duration) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:    "string",
+		Format:  "duration",
+		Pattern: `^-?P(-?\d+W|(-?\d+Y)?(-?\d+M)?(-?\d+D)?(T(-?\d+H)?(-?\d+M)?(-?[\d.]+S)?)?)$`,
+	}
+}